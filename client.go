@@ -0,0 +1,158 @@
+package imageflux
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultClient is the Client used by Image.Fetch.
+var DefaultClient = &Client{}
+
+// Client fetches the processed bytes of Images from ImageFlux over HTTP.
+type Client struct {
+	// Transport is used to perform requests. http.DefaultTransport is
+	// used if nil.
+	Transport http.RoundTripper
+
+	// MaxRetries is the number of additional attempts made for a request
+	// that fails with a 5xx response or a transport error. The default,
+	// zero, means no retries.
+	MaxRetries int
+
+	// Backoff returns how long to wait before retry number attempt
+	// (starting at 1). The default is 200ms * attempt.
+	Backoff func(attempt int) time.Duration
+
+	// OnMetrics, if non-nil, is called once for every successful
+	// response with metrics about the request.
+	OnMetrics func(Metrics)
+}
+
+// Metrics describes the outcome of a single Client.Fetch call.
+type Metrics struct {
+	// BytesDownloaded is the size of the response body, in bytes, or -1
+	// if unknown.
+	BytesDownloaded int64
+
+	// Cache reports whether the response was served from ImageFlux's
+	// cache, determined from its response headers.
+	Cache CacheStatus
+}
+
+// CacheStatus describes whether a response was served from cache.
+type CacheStatus int
+
+const (
+	// CacheUnknown means the response did not carry a recognized cache
+	// status header.
+	CacheUnknown CacheStatus = iota
+
+	// CacheHit means the response was served from ImageFlux's cache.
+	CacheHit
+
+	// CacheMiss means the response was generated fresh by ImageFlux.
+	CacheMiss
+)
+
+// Fetch fetches img using DefaultClient. See Client.Fetch.
+func (img *Image) Fetch(ctx context.Context) (io.ReadCloser, http.Header, error) {
+	return DefaultClient.Fetch(ctx, img)
+}
+
+// Fetch GETs img's signed URL, following ImageFlux's content negotiation
+// and retry semantics: it automatically negotiates "Accept: image/webp"
+// when img.Config.Format is FormatAuto, and retries idempotently with
+// backoff on 5xx responses or transport errors, up to c.MaxRetries times.
+func (c *Client) Fetch(ctx context.Context, img *Image) (io.ReadCloser, http.Header, error) {
+	u := img.SignedURL()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if accept := acceptHeader(img.Config); accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	client := &http.Client{Transport: c.Transport}
+
+	var resp *http.Response
+	var lastErr error
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, c.backoff(attempt)); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		resp, lastErr = client.Do(req)
+		if lastErr == nil && resp.StatusCode < http.StatusInternalServerError {
+			break
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+	}
+	if lastErr != nil {
+		return nil, nil, lastErr
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, resp.Header, fmt.Errorf("imageflux: GET %s: %s", u, resp.Status)
+	}
+
+	if c.OnMetrics != nil {
+		c.OnMetrics(Metrics{
+			BytesDownloaded: resp.ContentLength,
+			Cache:           cacheStatus(resp.Header),
+		})
+	}
+
+	return resp.Body, resp.Header, nil
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	if c.Backoff != nil {
+		return c.Backoff(attempt)
+	}
+	return time.Duration(attempt) * 200 * time.Millisecond
+}
+
+// acceptHeader returns the Accept header to negotiate WebP when cfg
+// leaves the output format up to ImageFlux.
+func acceptHeader(cfg *Config) string {
+	if cfg == nil || cfg.Format != FormatAuto {
+		return ""
+	}
+	return "image/webp,*/*"
+}
+
+// cacheStatus determines cache hit/miss from ImageFlux's X-Cache
+// response header.
+func cacheStatus(h http.Header) CacheStatus {
+	switch v := strings.ToUpper(h.Get("X-Cache")); {
+	case strings.Contains(v, "HIT"):
+		return CacheHit
+	case strings.Contains(v, "MISS"):
+		return CacheMiss
+	default:
+		return CacheUnknown
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,131 @@
+package imageflux
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testImage(t *testing.T, srv *httptest.Server, cfg *Config) *Image {
+	t.Helper()
+
+	host := strings.TrimPrefix(srv.URL, "https://")
+	return &Image{
+		Path:   "a.jpg",
+		Proxy:  &Proxy{Host: host},
+		Config: cfg,
+	}
+}
+
+func TestClientFetchSuccess(t *testing.T) {
+	var gotAccept string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAccept = r.Header.Get("Accept")
+		w.Header().Set("X-Cache", "HIT")
+		w.Write([]byte("image bytes"))
+	}))
+	defer srv.Close()
+
+	client := &Client{Transport: srv.Client().Transport}
+	img := testImage(t, srv, &Config{Format: FormatAuto})
+
+	var metrics Metrics
+	client.OnMetrics = func(m Metrics) { metrics = m }
+
+	rc, header, err := client.Fetch(context.Background(), img)
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	defer rc.Close()
+
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if string(body) != "image bytes" {
+		t.Errorf("body = %q, want %q", body, "image bytes")
+	}
+	if header.Get("X-Cache") != "HIT" {
+		t.Errorf("header X-Cache = %q, want %q", header.Get("X-Cache"), "HIT")
+	}
+	if gotAccept != "image/webp,*/*" {
+		t.Errorf("Accept header = %q, want %q", gotAccept, "image/webp,*/*")
+	}
+	if metrics.Cache != CacheHit {
+		t.Errorf("metrics.Cache = %v, want CacheHit", metrics.Cache)
+	}
+}
+
+func TestClientFetchRetriesOn5xx(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := &Client{
+		Transport:  srv.Client().Transport,
+		MaxRetries: 1,
+		Backoff:    func(int) time.Duration { return 0 },
+	}
+	img := testImage(t, srv, nil)
+
+	rc, _, err := client.Fetch(context.Background(), img)
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	defer rc.Close()
+
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("server was called %d times, want 2", got)
+	}
+
+	body, _ := io.ReadAll(rc)
+	if string(body) != "ok" {
+		t.Errorf("body = %q, want %q", body, "ok")
+	}
+}
+
+func TestClientFetchReturnsErrorAfterExhaustingRetries(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client := &Client{
+		Transport:  srv.Client().Transport,
+		MaxRetries: 2,
+		Backoff:    func(int) time.Duration { return 0 },
+	}
+	img := testImage(t, srv, nil)
+
+	if _, _, err := client.Fetch(context.Background(), img); err == nil {
+		t.Error("Fetch with persistent 500s returned nil error")
+	}
+}
+
+func TestAcceptHeader(t *testing.T) {
+	tests := []struct {
+		cfg  *Config
+		want string
+	}{
+		{nil, ""},
+		{&Config{}, ""},
+		{&Config{Format: FormatJPEG}, ""},
+		{&Config{Format: FormatAuto}, "image/webp,*/*"},
+	}
+	for _, tt := range tests {
+		if got := acceptHeader(tt.cfg); got != tt.want {
+			t.Errorf("acceptHeader(%+v) = %q, want %q", tt.cfg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package imageflux
+
+import "testing"
+
+func TestConfigStringWebP(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		want string
+	}{
+		{
+			name: "jpeg quality used for jpeg format",
+			cfg: &Config{
+				Format:      FormatJPEG,
+				Quality:     50,
+				JPEGQuality: 90,
+				WebPQuality: 10,
+			},
+			want: "f=jpg,q=90",
+		},
+		{
+			name: "webp quality used for webp format",
+			cfg: &Config{
+				Format:      FormatWebPAuto,
+				Quality:     50,
+				JPEGQuality: 90,
+				WebPQuality: 10,
+			},
+			want: "f=webp,q=10",
+		},
+		{
+			name: "falls back to quality when per-format quality unset",
+			cfg: &Config{
+				Format:  FormatWebPFromJPEG,
+				Quality: 42,
+			},
+			want: "f=webp:jpeg,q=42",
+		},
+		{
+			name: "webp hint and lossless",
+			cfg: &Config{
+				Format:       FormatWebPAuto,
+				WebPHint:     WebPHintPhoto,
+				LosslessWebP: true,
+			},
+			want: "f=webp,hint=photo,lossless=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.String(); got != tt.want {
+				t.Errorf("Config.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
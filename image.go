@@ -30,11 +30,32 @@ type Config struct {
 	Origin         Origin
 	Background     color.Color
 
-	// TODO: Overlay Parameters.
+	// Overlay Parameters.
+	Overlays []Overlay
 
 	// Output Parameters.
-	Format              Format
-	Quality             int
+	Format Format
+
+	// Quality is the quality of the output image. It is used as a
+	// fallback when JPEGQuality or WebPQuality is not set for the
+	// format being output.
+	Quality int
+
+	// JPEGQuality is the quality used when the output format is JPEG,
+	// overriding Quality.
+	JPEGQuality int
+
+	// WebPQuality is the quality used when the output format is WebP,
+	// overriding Quality.
+	WebPQuality int
+
+	// WebPHint is a hint about the kind of image being encoded, which
+	// WebP uses to choose better encoding parameters.
+	WebPHint WebPHint
+
+	// LosslessWebP encodes the output as lossless WebP.
+	LosslessWebP bool
+
 	DisableOptimization bool
 }
 
@@ -100,8 +121,53 @@ const (
 	// FormatWebPFromPNG encodes the image as a WebP.
 	// The input image should be a PNG.
 	FormatWebPFromPNG Format = "webp:png"
+
+	// FormatWebPAuto encodes the image as a WebP regardless of the
+	// input image's format, letting ImageFlux choose the source decoder.
+	FormatWebPAuto Format = "webp"
+)
+
+// WebPHint is a hint about the kind of image being encoded as WebP,
+// passed to ImageFlux's WebP encoder to improve quality and compression.
+type WebPHint string
+
+const (
+	// WebPHintDefault lets ImageFlux choose the encoding parameters.
+	WebPHintDefault WebPHint = ""
+
+	// WebPHintPicture is tuned for digital pictures, like portraits and
+	// indoor shots.
+	WebPHintPicture WebPHint = "picture"
+
+	// WebPHintPhoto is tuned for outdoor photographs with natural
+	// lighting.
+	WebPHintPhoto WebPHint = "photo"
+
+	// WebPHintDrawing is tuned for discrete-tone images, like line
+	// drawings and illustrations.
+	WebPHintDrawing WebPHint = "drawing"
+
+	// WebPHintIcon is tuned for small-sized colorful images.
+	WebPHintIcon WebPHint = "icon"
+
+	// WebPHintText is tuned for images that are dominated by text.
+	WebPHintText WebPHint = "text"
 )
 
+// isJPEG reports whether f encodes its output as JPEG.
+func isJPEG(f Format) bool {
+	return f == FormatJPEG
+}
+
+// isWebP reports whether f encodes its output as WebP.
+func isWebP(f Format) bool {
+	switch f {
+	case FormatWebPFromJPEG, FormatWebPFromPNG, FormatWebPAuto:
+		return true
+	}
+	return false
+}
+
 func (c *Config) String() string {
 	if c == nil {
 		return ""
@@ -132,19 +198,14 @@ func (c *Config) String() string {
 		buf = append(buf, ',')
 	}
 	if c.Background != nil {
-		r, g, b, a := c.Background.RGBA()
-		if a == 0xffff {
-			c := fmt.Sprintf("b=%02x%02x%02x,", r>>8, g>>8, b>>8)
-			buf = append(buf, c...)
-		} else if a == 0 {
-			buf = append(buf, "b=000000"...)
-		} else {
-			r = (r * 0xffff) / a
-			g = (g * 0xffff) / a
-			b = (b * 0xffff) / a
-			c := fmt.Sprintf("b=%02x%02x%02x,", r>>8, g>>8, b>>8)
-			buf = append(buf, c...)
-		}
+		buf = append(buf, 'b', '=')
+		buf = append(buf, colorHex(c.Background)...)
+		buf = append(buf, ',')
+	}
+	for _, ov := range c.Overlays {
+		buf = append(buf, 'l', '=', '(')
+		buf = append(buf, ov.String()...)
+		buf = append(buf, ')', ',')
 	}
 
 	if c.Format != "" {
@@ -152,9 +213,25 @@ func (c *Config) String() string {
 		buf = append(buf, c.Format...)
 		buf = append(buf, ',')
 	}
-	if c.Quality != 0 {
+	if c.WebPHint != WebPHintDefault {
+		buf = append(buf, "hint="...)
+		buf = append(buf, c.WebPHint...)
+		buf = append(buf, ',')
+	}
+	if c.LosslessWebP {
+		buf = append(buf, "lossless=1,"...)
+	}
+
+	quality := c.Quality
+	switch {
+	case isJPEG(c.Format) && c.JPEGQuality != 0:
+		quality = c.JPEGQuality
+	case isWebP(c.Format) && c.WebPQuality != 0:
+		quality = c.WebPQuality
+	}
+	if quality != 0 {
 		buf = append(buf, 'q', '=')
-		buf = strconv.AppendInt(buf, int64(c.Quality), 10)
+		buf = strconv.AppendInt(buf, int64(quality), 10)
 		buf = append(buf, ',')
 	}
 	if c.DisableOptimization {
@@ -167,6 +244,21 @@ func (c *Config) String() string {
 	return string(buf[:len(buf)-1])
 }
 
+// colorHex encodes c as the "RRGGBB" hex form used by ImageFlux's
+// background and overlay color parameters.
+func colorHex(c color.Color) string {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return "000000"
+	}
+	if a != 0xffff {
+		r = (r * 0xffff) / a
+		g = (g * 0xffff) / a
+		b = (b * 0xffff) / a
+	}
+	return fmt.Sprintf("%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
 func (a AspectMode) String() string {
 	switch a {
 	case AspectModeDefault:
@@ -0,0 +1,51 @@
+package local
+
+import (
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	imageflux "github.com/furusax0621/go-imageflux"
+)
+
+// WebPEncoder encodes m as WebP to w. lossless and quality come from the
+// rendered Image's Config (Config.LosslessWebP, and Config.WebPQuality
+// falling back to Config.Quality; zero if neither is set). Implementations are expected to wrap a third-party WebP
+// codec, e.g. github.com/chai2010/webp or github.com/kolesa-team/go-webp.
+type WebPEncoder interface {
+	Encode(w io.Writer, m image.Image, lossless bool, quality int) error
+}
+
+func (r LocalRenderer) encode(w io.Writer, m image.Image, cfg *imageflux.Config) error {
+	if cfg == nil {
+		cfg = &imageflux.Config{}
+	}
+
+	switch cfg.Format {
+	case imageflux.FormatPNG:
+		return png.Encode(w, m)
+	case imageflux.FormatGIF:
+		return gif.Encode(w, m, nil)
+	case imageflux.FormatWebPFromJPEG, imageflux.FormatWebPFromPNG, imageflux.FormatWebPAuto:
+		if r.WebPEncoder == nil {
+			return fmt.Errorf("local: rendering %s requires a WebPEncoder", cfg.Format)
+		}
+		quality := cfg.WebPQuality
+		if quality == 0 {
+			quality = cfg.Quality
+		}
+		return r.WebPEncoder.Encode(w, m, cfg.LosslessWebP, quality)
+	default: // FormatAuto, FormatJPEG, and anything unrecognized.
+		quality := cfg.JPEGQuality
+		if quality == 0 {
+			quality = cfg.Quality
+		}
+		if quality == 0 {
+			quality = jpeg.DefaultQuality
+		}
+		return jpeg.Encode(w, m, &jpeg.Options{Quality: quality})
+	}
+}
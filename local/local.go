@@ -0,0 +1,49 @@
+// Package local renders imageflux.Images locally, using Go's standard
+// image codecs instead of sending the request to ImageFlux. It exists
+// for dev/offline/test parity: unit tests and local previews can render
+// approximately what production ImageFlux would serve, without network
+// access.
+package local
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	imageflux "github.com/furusax0621/go-imageflux"
+)
+
+// Renderer produces the rendered bytes of an Image.
+type Renderer interface {
+	// Render returns a reader over the encoded bytes of img as
+	// transformed by img.Config. The caller must close it.
+	Render(img *imageflux.Image) (io.ReadCloser, error)
+}
+
+// RemoteRenderer renders an Image by fetching it from ImageFlux itself,
+// i.e. the behavior of the rest of this module: build the signed URL and
+// GET it. It performs no local decoding or transformation.
+type RemoteRenderer struct {
+	// Client is used to perform the request. http.DefaultClient is used
+	// if nil.
+	Client *http.Client
+}
+
+// Render fetches img.SignedURL() and returns its response body.
+func (r RemoteRenderer) Render(img *imageflux.Image) (io.ReadCloser, error) {
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	u := img.SignedURL()
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("local: GET %s: %s", u, resp.Status)
+	}
+	return resp.Body, nil
+}
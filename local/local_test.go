@@ -0,0 +1,89 @@
+package local
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"testing/fstest"
+
+	imageflux "github.com/furusax0621/go-imageflux"
+)
+
+func testSourceFS(t *testing.T) fstest.MapFS {
+	t.Helper()
+
+	src := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			src.Set(x, y, color.White)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("failed to encode test fixture: %v", err)
+	}
+
+	return fstest.MapFS{
+		"source.png": &fstest.MapFile{Data: buf.Bytes()},
+	}
+}
+
+func TestLocalRendererNilConfig(t *testing.T) {
+	r := LocalRenderer{FS: testSourceFS(t)}
+
+	rc, err := r.Render(&imageflux.Image{Path: "source.png"})
+	if err != nil {
+		t.Fatalf("Render with nil Config returned error: %v", err)
+	}
+	defer rc.Close()
+
+	if _, err := jpeg.Decode(rc); err != nil {
+		t.Errorf("Render with nil Config did not produce a valid JPEG: %v", err)
+	}
+}
+
+func TestLocalRendererForceScale(t *testing.T) {
+	r := LocalRenderer{FS: testSourceFS(t)}
+
+	img := &imageflux.Image{
+		Path: "source.png",
+		Config: &imageflux.Config{
+			Width:      20,
+			Height:     20,
+			AspectMode: imageflux.AspectModeForceScale,
+			Format:     imageflux.FormatPNG,
+		},
+	}
+
+	rc, err := r.Render(img)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	defer rc.Close()
+
+	out, err := png.Decode(rc)
+	if err != nil {
+		t.Fatalf("Render did not produce a valid PNG: %v", err)
+	}
+
+	if b := out.Bounds(); b.Dx() != 20 || b.Dy() != 20 {
+		t.Errorf("Render produced bounds %v, want 20x20", b)
+	}
+}
+
+func TestLocalRendererWebPWithoutEncoder(t *testing.T) {
+	r := LocalRenderer{FS: testSourceFS(t)}
+
+	img := &imageflux.Image{
+		Path:   "source.png",
+		Config: &imageflux.Config{Format: imageflux.FormatWebPAuto},
+	}
+
+	if _, err := r.Render(img); err == nil {
+		t.Error("Render with WebP format and no WebPEncoder returned nil error")
+	}
+}
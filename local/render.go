@@ -0,0 +1,206 @@
+package local
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/fs"
+
+	imageflux "github.com/furusax0621/go-imageflux"
+	xdraw "golang.org/x/image/draw"
+)
+
+// LocalRenderer renders an Image against a local image source, applying
+// the same scaling, cropping, padding and format conversion that
+// ImageFlux would apply on the real service. An unset Format or
+// FormatAuto is encoded as JPEG whatever the source image's format.
+//
+// LocalRenderer is a best-effort approximation of ImageFlux's own
+// processing, not a bit-for-bit reimplementation: it is meant to give
+// tests and local previews a result close enough to catch real mistakes
+// without requiring network access.
+type LocalRenderer struct {
+	// FS is the filesystem Image.Path is resolved against.
+	FS fs.FS
+
+	// WebPEncoder encodes the rendered image as WebP. It is only
+	// required when rendering an Image whose Config.Format requests
+	// WebP output. go-imageflux does not depend on any WebP codec
+	// directly, so wire in github.com/chai2010/webp,
+	// github.com/kolesa-team/go-webp, or similar.
+	WebPEncoder WebPEncoder
+}
+
+// Render decodes img.Path from r.FS, applies img.Config, and returns the
+// encoded result.
+func (r LocalRenderer) Render(img *imageflux.Image) (io.ReadCloser, error) {
+	f, err := r.FS.Open(img.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("local: decode %s: %w", img.Path, err)
+	}
+
+	out := transform(src, img.Config)
+
+	var buf bytes.Buffer
+	if err := r.encode(&buf, out, img.Config); err != nil {
+		return nil, fmt.Errorf("local: encode %s: %w", img.Path, err)
+	}
+	return io.NopCloser(&buf), nil
+}
+
+// transform applies cfg's scaling, cropping, padding and origin to src.
+func transform(src image.Image, cfg *imageflux.Config) image.Image {
+	if cfg == nil {
+		return src
+	}
+
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	if sw == 0 || sh == 0 {
+		return src
+	}
+
+	tw, th := targetSize(sw, sh, cfg)
+	if tw == sw && th == sh {
+		return src
+	}
+	if cfg.DisableEnlarge && (tw > sw || th > sh) {
+		tw, th = sw, sh
+	}
+
+	switch cfg.AspectMode {
+	case imageflux.AspectModeCrop:
+		return cropScale(src, tw, th, cfg.Origin)
+	case imageflux.AspectModePad:
+		return padScale(src, tw, th, cfg.Origin, cfg.Background)
+	case imageflux.AspectModeForceScale:
+		return scale(src, tw, th)
+	default: // AspectModeDefault, AspectModeScale
+		fw, fh := fitSize(sw, sh, tw, th)
+		return scale(src, fw, fh)
+	}
+}
+
+// targetSize resolves the requested width/height, filling in whichever
+// dimension is left zero from the source's aspect ratio.
+func targetSize(sw, sh int, cfg *imageflux.Config) (w, h int) {
+	w, h = cfg.Width, cfg.Height
+	switch {
+	case w == 0 && h == 0:
+		return sw, sh
+	case w == 0:
+		return sw * h / sh, h
+	case h == 0:
+		return w, sh * w / sw
+	default:
+		return w, h
+	}
+}
+
+// fitSize scales (sw, sh) to fit within (maxW, maxH) preserving aspect
+// ratio.
+func fitSize(sw, sh, maxW, maxH int) (w, h int) {
+	if maxW == 0 || maxH == 0 {
+		return sw, sh
+	}
+	srcRatio := float64(sw) / float64(sh)
+	boxRatio := float64(maxW) / float64(maxH)
+	if srcRatio > boxRatio {
+		return maxW, int(float64(maxW) / srcRatio)
+	}
+	return int(float64(maxH) * srcRatio), maxH
+}
+
+func scale(src image.Image, w, h int) image.Image {
+	if w <= 0 || h <= 0 {
+		return src
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Over, nil)
+	return dst
+}
+
+// cropScale scales src to cover (w, h) and crops the overflow, anchored
+// at origin.
+func cropScale(src image.Image, w, h int, origin imageflux.Origin) image.Image {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+
+	coverW, coverH := w, h
+	srcRatio := float64(sw) / float64(sh)
+	boxRatio := float64(w) / float64(h)
+	if srcRatio > boxRatio {
+		coverW = int(float64(h) * srcRatio)
+	} else {
+		coverH = int(float64(w) / srcRatio)
+	}
+
+	covered := scale(src, coverW, coverH)
+
+	fx, fy := originAnchor(origin)
+	x0 := int(float64(coverW-w) * fx)
+	y0 := int(float64(coverH-h) * fy)
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), covered, image.Pt(x0, y0), draw.Src)
+	return dst
+}
+
+// padScale scales src to fit within (w, h) and pads the remainder with
+// bg, anchored at origin.
+func padScale(src image.Image, w, h int, origin imageflux.Origin, bg color.Color) image.Image {
+	sb := src.Bounds()
+	fw, fh := fitSize(sb.Dx(), sb.Dy(), w, h)
+	fitted := scale(src, fw, fh)
+
+	if bg == nil {
+		bg = color.Transparent
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+
+	fx, fy := originAnchor(origin)
+	x0 := int(float64(w-fw) * fx)
+	y0 := int(float64(h-fh) * fy)
+	draw.Draw(dst, image.Rect(x0, y0, x0+fw, y0+fh), fitted, image.Point{}, draw.Over)
+
+	return dst
+}
+
+// originAnchor returns the fractional (x, y) anchor point in [0, 1] for
+// o, used to position a crop window or padded image.
+func originAnchor(o imageflux.Origin) (x, y float64) {
+	switch o {
+	case imageflux.OriginTopLeft:
+		return 0, 0
+	case imageflux.OriginTopCenter:
+		return 0.5, 0
+	case imageflux.OriginTopRight:
+		return 1, 0
+	case imageflux.OriginMiddleLeft:
+		return 0, 0.5
+	case imageflux.OriginMiddleRight:
+		return 1, 0.5
+	case imageflux.OriginBottomLeft:
+		return 0, 1
+	case imageflux.OriginBottomCenter:
+		return 0.5, 1
+	case imageflux.OriginBottomRight:
+		return 1, 1
+	default: // OriginDefault, OriginMiddleCenter
+		return 0.5, 0.5
+	}
+}
@@ -0,0 +1,142 @@
+package imageflux
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// Overlay is an image overlaid on top of the base image, serialized as a
+// nested "l=(...)" parameter.
+type Overlay struct {
+	// Path is the path of the overlay image.
+	Path string
+
+	// X and Y are the offset of the overlay from its Origin, in pixels.
+	X int
+	Y int
+
+	// Scaling Parameters.
+	Width          int
+	Height         int
+	DisableEnlarge bool
+	AspectMode     AspectMode
+	Origin         Origin
+	Background     color.Color
+
+	// Opacity is the opacity of the overlay, in the range [0, 100].
+	// Zero means the value is omitted and ImageFlux's default is used.
+	Opacity int
+
+	// Blend is the blend mode used to composite the overlay onto the
+	// base image.
+	Blend Blend
+}
+
+// Blend is the blend mode of an Overlay.
+type Blend string
+
+const (
+	// BlendNormal composites the overlay with normal alpha blending.
+	BlendNormal Blend = ""
+
+	// BlendMultiply multiplies the overlay with the base image.
+	BlendMultiply Blend = "multiply"
+
+	// BlendScreen screens the overlay onto the base image.
+	BlendScreen Blend = "screen"
+
+	// BlendDarken keeps the darker of the overlay and base pixels.
+	BlendDarken Blend = "darken"
+
+	// BlendLighten keeps the lighter of the overlay and base pixels.
+	BlendLighten Blend = "lighten"
+)
+
+// NewOverlay returns an Overlay for the image at path.
+func NewOverlay(path string) Overlay {
+	return Overlay{Path: path}
+}
+
+// String returns the string representation of the overlay, i.e. the
+// contents of the "l=(...)" parameter without the surrounding parentheses.
+func (o Overlay) String() string {
+	var buf []byte
+	buf = append(buf, 'p', '=')
+	buf = append(buf, escapeOverlayPath(o.Path)...)
+	buf = append(buf, ',')
+
+	if o.Width != 0 {
+		buf = append(buf, 'w', '=')
+		buf = strconv.AppendInt(buf, int64(o.Width), 10)
+		buf = append(buf, ',')
+	}
+	if o.Height != 0 {
+		buf = append(buf, 'h', '=')
+		buf = strconv.AppendInt(buf, int64(o.Height), 10)
+		buf = append(buf, ',')
+	}
+	if o.DisableEnlarge {
+		buf = append(buf, 'u', '=', '0', ',')
+	}
+	if o.AspectMode != AspectModeDefault {
+		buf = append(buf, 'a', '=')
+		buf = strconv.AppendInt(buf, int64(o.AspectMode-1), 10)
+		buf = append(buf, ',')
+	}
+	if o.Origin != OriginDefault {
+		buf = append(buf, 'g', '=')
+		buf = strconv.AppendInt(buf, int64(o.Origin), 10)
+		buf = append(buf, ',')
+	}
+	if o.Background != nil {
+		buf = append(buf, 'b', '=')
+		buf = append(buf, colorHex(o.Background)...)
+		buf = append(buf, ',')
+	}
+	if o.X != 0 {
+		buf = append(buf, 'x', '=')
+		buf = strconv.AppendInt(buf, int64(o.X), 10)
+		buf = append(buf, ',')
+	}
+	if o.Y != 0 {
+		buf = append(buf, 'y', '=')
+		buf = strconv.AppendInt(buf, int64(o.Y), 10)
+		buf = append(buf, ',')
+	}
+	if o.Opacity != 0 {
+		buf = append(buf, 'o', '=')
+		buf = strconv.AppendInt(buf, int64(o.Opacity), 10)
+		buf = append(buf, ',')
+	}
+	if o.Blend != BlendNormal {
+		buf = append(buf, 'm', '=')
+		buf = append(buf, o.Blend...)
+		buf = append(buf, ',')
+	}
+
+	if len(buf) == 0 {
+		return ""
+	}
+	return string(buf[:len(buf)-1])
+}
+
+// escapeOverlayPath escapes the characters that are significant to the
+// overlay parameter list ('(', ')' and ',') so that an overlay path
+// containing them round-trips through Config.String.
+func escapeOverlayPath(s string) string {
+	if !strings.ContainsAny(s, `\(),`) {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch r {
+		case '\\', '(', ')', ',':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
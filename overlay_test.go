@@ -0,0 +1,84 @@
+package imageflux
+
+import "testing"
+
+func TestOverlayStringRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{
+			name: "simple",
+			cfg: &Config{
+				Width: 100,
+				Overlays: []Overlay{
+					NewOverlay("watermark.png"),
+				},
+			},
+		},
+		{
+			name: "full",
+			cfg: &Config{
+				Overlays: []Overlay{
+					{
+						Path:       "badges/gold.png",
+						X:          10,
+						Y:          20,
+						Width:      50,
+						Height:     50,
+						AspectMode: AspectModeCrop,
+						Origin:     OriginBottomRight,
+						Opacity:    80,
+						Blend:      BlendMultiply,
+					},
+				},
+			},
+		},
+		{
+			name: "path with comma",
+			cfg: &Config{
+				Overlays: []Overlay{
+					NewOverlay("a,b.jpg"),
+				},
+			},
+		},
+		{
+			name: "path with parens and comma",
+			cfg: &Config{
+				Overlays: []Overlay{
+					NewOverlay("weird (1,2).png"),
+				},
+			},
+		},
+		{
+			name: "multiple overlays",
+			cfg: &Config{
+				Overlays: []Overlay{
+					NewOverlay("a,1.png"),
+					NewOverlay("b(2).png"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.cfg.String()
+
+			got, err := ParseConfig(s)
+			if err != nil {
+				t.Fatalf("ParseConfig(%q) returned error: %v", s, err)
+			}
+
+			if len(got.Overlays) != len(tt.cfg.Overlays) {
+				t.Fatalf("ParseConfig(%q).Overlays = %#v, want %#v", s, got.Overlays, tt.cfg.Overlays)
+			}
+			for i, ov := range got.Overlays {
+				want := tt.cfg.Overlays[i]
+				if ov != want {
+					t.Errorf("ParseConfig(%q).Overlays[%d] = %#v, want %#v", s, i, ov, want)
+				}
+			}
+		})
+	}
+}
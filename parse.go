@@ -0,0 +1,308 @@
+package imageflux
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"image/color"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrBadSignature is returned by (*Proxy).Parse when the Proxy has a
+// secret and the URL's "sig=" parameter is missing or does not match the
+// signature computed from that secret.
+var ErrBadSignature = errors.New("imageflux: bad signature")
+
+// Parse parses rawurl as an ImageFlux URL produced by Image.URL or
+// Image.SignedURL, returning the Image it describes. The returned
+// Image's Proxy only has its Host populated, so any "sig=" parameter is
+// left unverified; use (*Proxy).Parse to verify against a known secret.
+func Parse(rawurl string) (*Image, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return parse(u, &Proxy{Host: u.Host})
+}
+
+// Parse parses rawurl as an ImageFlux URL served from p, returning the
+// Image it describes. If p.Secret is set, the URL must carry a "sig="
+// parameter matching that secret; ErrBadSignature is returned if it is
+// missing or does not match.
+func (p *Proxy) Parse(rawurl string) (*Image, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return parse(u, p)
+}
+
+func parse(u *url.URL, proxy *Proxy) (*Image, error) {
+	_, paramList, sig, imgPath, err := splitPath(u.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := ParseConfig(paramList)
+	if err != nil {
+		return nil, err
+	}
+
+	img := &Image{Path: imgPath, Proxy: proxy, Config: cfg}
+
+	if proxy != nil && proxy.Secret != "" {
+		expected := img.Sign()
+		if sig == "" || expected == "" || !hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil, ErrBadSignature
+		}
+	}
+
+	return img, nil
+}
+
+// splitPath splits the path of an ImageFlux URL into its "c"/"c!" prefix,
+// its comma-separated config parameter list (with any leading "sig="
+// parameter removed), the signature carried by that "sig=" parameter (if
+// any), and the path of the underlying image. A path carrying neither
+// prefix is an unconfigured image, i.e. one built from Image.URL with a
+// zero Config; it is returned as-is, with an empty prefix and paramList.
+func splitPath(p string) (prefix, paramList, sig, imgPath string, err error) {
+	p = strings.TrimPrefix(p, "/")
+
+	rest, ok := cutPrefix(p, "c!/")
+	if ok {
+		prefix = "c!"
+	} else if rest, ok = cutPrefix(p, "c/"); ok {
+		prefix = "c"
+	} else {
+		return "", "", "", p, nil
+	}
+
+	// The parameter list ends at the first '/' outside any "(...)"
+	// overlay, so that an overlay path containing '/' stays intact.
+	end, depth := -1, 0
+	for i := 0; i < len(rest) && end < 0; i++ {
+		switch rest[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '/':
+			if depth == 0 {
+				end = i
+			}
+		}
+	}
+	if end < 0 {
+		return "", "", "", "", fmt.Errorf("imageflux: missing image path: %q", p)
+	}
+	paramList, imgPath = rest[:end], rest[end+1:]
+
+	if s, ok := cutPrefix(paramList, "sig="); ok {
+		if i := strings.IndexByte(s, ','); i >= 0 {
+			sig, paramList = s[:i], s[i+1:]
+		} else {
+			sig, paramList = s, ""
+		}
+	}
+
+	return prefix, paramList, sig, imgPath, nil
+}
+
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// ParseConfig parses s, the comma-separated parameter list produced by
+// Config.String, into a Config.
+func ParseConfig(s string) (*Config, error) {
+	if s == "" {
+		return &Config{}, nil
+	}
+
+	cfg := &Config{}
+	for _, param := range splitParams(s) {
+		key, val, ok := strings.Cut(param, "=")
+		if !ok {
+			return nil, fmt.Errorf("imageflux: malformed parameter %q", param)
+		}
+
+		var err error
+		switch key {
+		case "w":
+			cfg.Width, err = strconv.Atoi(val)
+		case "h":
+			cfg.Height, err = strconv.Atoi(val)
+		case "u":
+			cfg.DisableEnlarge = val == "0"
+		case "a":
+			var n int
+			if n, err = strconv.Atoi(val); err == nil {
+				cfg.AspectMode = AspectMode(n + 1)
+			}
+		case "g":
+			var n int
+			if n, err = strconv.Atoi(val); err == nil {
+				cfg.Origin = Origin(n)
+			}
+		case "b":
+			cfg.Background, err = colorFromHex(val)
+		case "l":
+			var ov Overlay
+			ov, err = parseOverlay(val)
+			if err == nil {
+				cfg.Overlays = append(cfg.Overlays, ov)
+			}
+		case "f":
+			cfg.Format = Format(val)
+		case "hint":
+			cfg.WebPHint = WebPHint(val)
+		case "lossless":
+			cfg.LosslessWebP = val == "1"
+		case "q":
+			cfg.Quality, err = strconv.Atoi(val)
+		case "o":
+			cfg.DisableOptimization = val == "0"
+		default:
+			return nil, fmt.Errorf("imageflux: unknown parameter %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("imageflux: parameter %q: %w", param, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// splitParams splits s on top-level commas, treating "(...)" as opaque so
+// that a nested overlay's own comma-separated parameters are kept
+// together with the "l=(...)" parameter that contains them, and treating
+// a backslash as escaping the character that follows it so an escaped
+// ',', '(' or ')' (as produced by escapeOverlayPath) never affects
+// splitting or depth tracking.
+func splitParams(s string) []string {
+	var params []string
+	depth := 0
+	start := 0
+	escaped := false
+	for i := 0; i < len(s); i++ {
+		if escaped {
+			escaped = false
+			continue
+		}
+		switch s[i] {
+		case '\\':
+			escaped = true
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				params = append(params, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	params = append(params, s[start:])
+	return params
+}
+
+// parseOverlay parses s, the value of an "l=(...)" parameter, into an
+// Overlay. The surrounding parentheses, if present, are removed first.
+func parseOverlay(s string) (Overlay, error) {
+	s = strings.TrimPrefix(s, "(")
+	s = strings.TrimSuffix(s, ")")
+
+	var ov Overlay
+	for _, param := range splitParams(s) {
+		key, val, ok := strings.Cut(param, "=")
+		if !ok {
+			return Overlay{}, fmt.Errorf("imageflux: malformed overlay parameter %q", param)
+		}
+
+		var err error
+		switch key {
+		case "p":
+			ov.Path = unescapeOverlayPath(val)
+		case "w":
+			ov.Width, err = strconv.Atoi(val)
+		case "h":
+			ov.Height, err = strconv.Atoi(val)
+		case "u":
+			ov.DisableEnlarge = val == "0"
+		case "a":
+			var n int
+			if n, err = strconv.Atoi(val); err == nil {
+				ov.AspectMode = AspectMode(n + 1)
+			}
+		case "g":
+			var n int
+			if n, err = strconv.Atoi(val); err == nil {
+				ov.Origin = Origin(n)
+			}
+		case "b":
+			ov.Background, err = colorFromHex(val)
+		case "x":
+			ov.X, err = strconv.Atoi(val)
+		case "y":
+			ov.Y, err = strconv.Atoi(val)
+		case "o":
+			ov.Opacity, err = strconv.Atoi(val)
+		case "m":
+			ov.Blend = Blend(val)
+		default:
+			return Overlay{}, fmt.Errorf("imageflux: unknown overlay parameter %q", key)
+		}
+		if err != nil {
+			return Overlay{}, fmt.Errorf("imageflux: overlay parameter %q: %w", param, err)
+		}
+	}
+
+	return ov, nil
+}
+
+// unescapeOverlayPath reverses escapeOverlayPath.
+func unescapeOverlayPath(s string) string {
+	if !strings.ContainsRune(s, '\\') {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	escaped := false
+	for _, r := range s {
+		if !escaped && r == '\\' {
+			escaped = true
+			continue
+		}
+		escaped = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// colorFromHex parses a "RRGGBB" hex string as produced by colorHex into
+// an opaque color.Color.
+func colorFromHex(s string) (color.Color, error) {
+	if len(s) != 6 {
+		return nil, fmt.Errorf("invalid color %q", s)
+	}
+	n, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q: %w", s, err)
+	}
+	return color.NRGBA{
+		R: uint8(n >> 16),
+		G: uint8(n >> 8),
+		B: uint8(n),
+		A: 0xff,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package imageflux
+
+import (
+	"testing"
+)
+
+func TestParseConfigRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{
+			name: "scaling and output parameters",
+			cfg: &Config{
+				Width:      200,
+				Height:     100,
+				AspectMode: AspectModePad,
+				Origin:     OriginBottomRight,
+				Format:     FormatJPEG,
+				Quality:    85,
+			},
+		},
+		{
+			name: "disable flags",
+			cfg: &Config{
+				DisableEnlarge:      true,
+				DisableOptimization: true,
+			},
+		},
+		{
+			name: "webp options",
+			cfg: &Config{
+				Format:       FormatWebPAuto,
+				WebPHint:     WebPHintDrawing,
+				LosslessWebP: true,
+				WebPQuality:  70,
+			},
+		},
+		{
+			name: "overlay with comma in path",
+			cfg: &Config{
+				Width: 300,
+				Overlays: []Overlay{
+					NewOverlay("watermarks/a,b.png"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.cfg.String()
+
+			got, err := ParseConfig(s)
+			if err != nil {
+				t.Fatalf("ParseConfig(%q) returned error: %v", s, err)
+			}
+
+			if got2 := got.String(); got2 != s {
+				t.Errorf("ParseConfig(%q).String() = %q, want %q", s, got2, s)
+			}
+		})
+	}
+}
+
+func TestParseImage(t *testing.T) {
+	proxy := &Proxy{Host: "example.com"}
+	img := &Image{
+		Path:  "path/to/image,with-comma.jpg",
+		Proxy: proxy,
+		Config: &Config{
+			Width: 100,
+			Overlays: []Overlay{
+				NewOverlay("badge-a,b.png"),
+			},
+		},
+	}
+
+	got, err := Parse(img.URL().String())
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", img.URL(), err)
+	}
+
+	if got.Path != img.Path {
+		t.Errorf("Parse(%q).Path = %q, want %q", img.URL(), got.Path, img.Path)
+	}
+	if got.Config.String() != img.Config.String() {
+		t.Errorf("Parse(%q).Config = %q, want %q", img.URL(), got.Config.String(), img.Config.String())
+	}
+}
+
+func TestParseImageWithZeroConfig(t *testing.T) {
+	img := &Image{
+		Path:  "a.jpg",
+		Proxy: &Proxy{Host: "example.com"},
+	}
+
+	got, err := Parse(img.URL().String())
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", img.URL(), err)
+	}
+
+	if got.Path != img.Path {
+		t.Errorf("Parse(%q).Path = %q, want %q", img.URL(), got.Path, img.Path)
+	}
+	if got.Config.String() != "" {
+		t.Errorf("Parse(%q).Config = %q, want empty", img.URL(), got.Config.String())
+	}
+}
+
+func TestProxyParseVerifiesSignature(t *testing.T) {
+	proxy := &Proxy{Host: "example.com", Secret: "sekrit"}
+	img := &Image{
+		Path:   "a.jpg",
+		Proxy:  proxy,
+		Config: &Config{Width: 100},
+	}
+
+	u := img.SignedURL().String()
+
+	got, err := proxy.Parse(u)
+	if err != nil {
+		t.Fatalf("Proxy.Parse(%q) returned error: %v", u, err)
+	}
+	if got.Path != img.Path {
+		t.Errorf("Proxy.Parse(%q).Path = %q, want %q", u, got.Path, img.Path)
+	}
+
+	otherProxy := &Proxy{Host: "example.com", Secret: "different"}
+	if _, err := otherProxy.Parse(u); err != ErrBadSignature {
+		t.Errorf("Proxy.Parse(%q) with wrong secret = %v, want ErrBadSignature", u, err)
+	}
+}
@@ -0,0 +1,122 @@
+package imageflux
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigError reports the ways in which a Config failed validation.
+// A single ConfigError may aggregate multiple violations.
+type ConfigError struct {
+	Violations []string
+}
+
+func (e *ConfigError) Error() string {
+	if len(e.Violations) == 1 {
+		return "imageflux: invalid config: " + e.Violations[0]
+	}
+	return fmt.Sprintf("imageflux: invalid config: %s", strings.Join(e.Violations, "; "))
+}
+
+// Validate reports whether c describes a config that ImageFlux will accept.
+// It returns a *ConfigError listing every violation it finds, or nil if c
+// is valid. A nil Config is always valid.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var violations []string
+	add := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if c.Width < 0 {
+		add("Width must not be negative, got %d", c.Width)
+	}
+	if c.Height < 0 {
+		add("Height must not be negative, got %d", c.Height)
+	}
+	validateQuality(add, "Quality", c.Quality)
+	validateQuality(add, "JPEGQuality", c.JPEGQuality)
+	validateQuality(add, "WebPQuality", c.WebPQuality)
+
+	if c.Format != "" && !c.Format.valid() {
+		add("unknown Format %q", c.Format)
+	}
+	if c.Origin != OriginDefault && c.AspectMode != AspectModeCrop && c.AspectMode != AspectModePad {
+		add("Origin is only meaningful when AspectMode is AspectModeCrop or AspectModePad")
+	}
+	if c.Background != nil && c.AspectMode != AspectModePad {
+		add("Background only has an effect when AspectMode is AspectModePad")
+	}
+
+	for i, ov := range c.Overlays {
+		if err := ov.Validate(); err != nil {
+			add("Overlays[%d]: %s", i, err)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ConfigError{Violations: violations}
+}
+
+// Build validates c and returns its string representation, i.e. the
+// contents of the "c/..." path segment of an Image's URL.
+func (c *Config) Build() (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+	return c.String(), nil
+}
+
+func validateQuality(add func(string, ...interface{}), name string, q int) {
+	if q != 0 && (q < 1 || q > 100) {
+		add("%s must be between 1 and 100, got %d", name, q)
+	}
+}
+
+// valid reports whether f is one of the known Format constants.
+func (f Format) valid() bool {
+	switch f {
+	case FormatAuto, FormatJPEG, FormatPNG, FormatGIF,
+		FormatWebPFromJPEG, FormatWebPFromPNG, FormatWebPAuto:
+		return true
+	}
+	return false
+}
+
+// Validate reports whether o describes an overlay that ImageFlux will
+// accept.
+func (o Overlay) Validate() error {
+	var violations []string
+	add := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if o.Path == "" {
+		add("Path must not be empty")
+	}
+	if o.Width < 0 {
+		add("Width must not be negative, got %d", o.Width)
+	}
+	if o.Height < 0 {
+		add("Height must not be negative, got %d", o.Height)
+	}
+	if o.Opacity != 0 && (o.Opacity < 0 || o.Opacity > 100) {
+		add("Opacity must be between 0 and 100, got %d", o.Opacity)
+	}
+	if o.Origin != OriginDefault && o.AspectMode != AspectModeCrop && o.AspectMode != AspectModePad {
+		add("Origin is only meaningful when AspectMode is AspectModeCrop or AspectModePad")
+	}
+	if o.Background != nil && o.AspectMode != AspectModePad {
+		add("Background only has an effect when AspectMode is AspectModePad")
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ConfigError{Violations: violations}
+}
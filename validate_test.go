@@ -0,0 +1,101 @@
+package imageflux
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			cfg: &Config{
+				Width:      100,
+				Height:     100,
+				AspectMode: AspectModePad,
+				Origin:     OriginTopLeft,
+				Background: color.Black,
+				Quality:    80,
+				Format:     FormatJPEG,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "negative width",
+			cfg:     &Config{Width: -1},
+			wantErr: true,
+		},
+		{
+			name:    "quality out of range",
+			cfg:     &Config{Quality: 101},
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			cfg:     &Config{Format: Format("bmp")},
+			wantErr: true,
+		},
+		{
+			name: "origin without crop or pad",
+			cfg: &Config{
+				AspectMode: AspectModeScale,
+				Origin:     OriginTopLeft,
+			},
+			wantErr: true,
+		},
+		{
+			name: "background without pad",
+			cfg: &Config{
+				AspectMode: AspectModeCrop,
+				Background: color.Black,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid overlay",
+			cfg: &Config{
+				Overlays: []Overlay{{}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "nil config",
+			cfg:     nil,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if _, ok := err.(*ConfigError); !ok {
+					t.Errorf("Validate() returned %T, want *ConfigError", err)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigBuild(t *testing.T) {
+	cfg := &Config{Width: 100, Quality: -1}
+	if _, err := cfg.Build(); err == nil {
+		t.Error("Build() with invalid config returned nil error")
+	}
+
+	cfg = &Config{Width: 100, Quality: 80}
+	s, err := cfg.Build()
+	if err != nil {
+		t.Fatalf("Build() returned error: %v", err)
+	}
+	if want := cfg.String(); s != want {
+		t.Errorf("Build() = %q, want %q", s, want)
+	}
+}